@@ -0,0 +1,42 @@
+// Package cache provides a thread-safe in-memory cache implementation.
+//
+// It supports TTL-based expiration and automatic cleanup of expired entries.
+//
+// Example usage:
+//
+//	c := cache.New(5 * time.Minute)
+//	c.Set("key", "value")
+//	value, ok := c.Get("key")
+//
+// Callers that want type safety on values, or that want to avoid boxing
+// values in interface{}, should use the generic Typed cache instead.
+package cache
+
+import "time"
+
+// Cache represents an in-memory key-value store with string keys and
+// expiration. It is a thin wrapper around Typed[string, interface{}],
+// kept so that existing callers keep working unchanged on top of the
+// generic underlying implementation.
+//
+// Cache is safe for concurrent use by multiple goroutines.
+type Cache struct {
+	*Typed[string, interface{}]
+}
+
+// New creates a new Cache with the specified default TTL.
+// It starts a background goroutine that expires items as they come due.
+//
+// Example:
+//
+//	cache := New(10 * time.Minute)
+//	defer cache.Close()
+func New(ttl time.Duration) *Cache {
+	return &Cache{Typed: NewTyped[string, interface{}](ttl)}
+}
+
+// NewWithOptions creates a new Cache with the specified default TTL and
+// behavior options. See Options for details.
+func NewWithOptions(ttl time.Duration, opts Options) *Cache {
+	return &Cache{Typed: NewTypedWithOptions[string, interface{}](ttl, opts)}
+}
@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOnNewItemFiresOnlyForFirstInsert(t *testing.T) {
+	c := NewTyped[string, int](time.Hour)
+	defer c.Close()
+
+	var newItemCalls int
+	c.OnNewItem(func(key string, value int) {
+		newItemCalls++
+	})
+
+	c.Set("a", 1)
+	c.Set("a", 2) // update, not a new item
+
+	if newItemCalls != 1 {
+		t.Fatalf("OnNewItem called %d times, want 1", newItemCalls)
+	}
+}
+
+func TestOnEvictedFiresWithEvictReplacedOnOverwrite(t *testing.T) {
+	c := NewTyped[string, int](time.Hour)
+	defer c.Close()
+
+	var gotReason EvictReason
+	var gotValue int
+	c.OnEvicted(func(key string, value int, reason EvictReason) {
+		gotReason = reason
+		gotValue = value
+	})
+
+	c.Set("a", 1)
+	c.Set("a", 2)
+
+	if gotReason != EvictReplaced {
+		t.Fatalf("reason = %v, want %v", gotReason, EvictReplaced)
+	}
+	if gotValue != 1 {
+		t.Fatalf("evicted value = %d, want 1 (the old value)", gotValue)
+	}
+}
+
+func TestOnEvictedFiresWithEvictDeletedOnDelete(t *testing.T) {
+	c := NewTyped[string, int](time.Hour)
+	defer c.Close()
+
+	var gotReason EvictReason
+	c.OnEvicted(func(key string, value int, reason EvictReason) {
+		gotReason = reason
+	})
+
+	c.Set("a", 1)
+	c.Delete("a")
+
+	if gotReason != EvictDeleted {
+		t.Fatalf("reason = %v, want %v", gotReason, EvictDeleted)
+	}
+}
+
+func TestOnEvictedFiresWithEvictClearedOnClear(t *testing.T) {
+	c := NewTyped[string, int](time.Hour)
+	defer c.Close()
+
+	var reasons []EvictReason
+	c.OnEvicted(func(key string, value int, reason EvictReason) {
+		reasons = append(reasons, reason)
+	})
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Clear()
+
+	if len(reasons) != 2 {
+		t.Fatalf("OnEvicted called %d times, want 2", len(reasons))
+	}
+	for _, r := range reasons {
+		if r != EvictCleared {
+			t.Fatalf("reason = %v, want %v", r, EvictCleared)
+		}
+	}
+}
+
+func TestOnExpiredFiresInAdditionToOnEvicted(t *testing.T) {
+	c := NewTyped[string, int](10 * time.Millisecond)
+	defer c.Close()
+
+	reasons := make(chan EvictReason, 1)
+	expired := make(chan struct{}, 1)
+	c.OnEvicted(func(key string, value int, reason EvictReason) {
+		reasons <- reason
+	})
+	c.OnExpired(func(key string, value int) {
+		expired <- struct{}{}
+	})
+
+	c.Set("a", 1)
+
+	select {
+	case <-expired:
+	case <-time.After(2 * time.Second):
+		t.Fatal("OnExpired was never called")
+	}
+
+	select {
+	case reason := <-reasons:
+		if reason != EvictExpired {
+			t.Fatalf("OnEvicted reason = %v, want %v", reason, EvictExpired)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("OnEvicted was never called")
+	}
+}
+
+func TestOnExpiredNotCalledForNonExpiryRemovals(t *testing.T) {
+	c := NewTyped[string, int](time.Hour)
+	defer c.Close()
+
+	var expiredCalled bool
+	c.OnExpired(func(key string, value int) {
+		expiredCalled = true
+	})
+
+	c.Set("a", 1)
+	c.Delete("a")
+
+	if expiredCalled {
+		t.Fatal("OnExpired should not be called for an explicit Delete")
+	}
+}
+
+func TestCallbacksCanReenterCache(t *testing.T) {
+	c := NewTyped[string, int](10 * time.Millisecond)
+	defer c.Close()
+
+	c.OnExpired(func(key string, value int) {
+		// Re-inserting from within the callback must not deadlock, since
+		// callbacks run outside the cache's internal lock.
+		c.Set(key+"-reinserted", value)
+	})
+
+	c.Set("a", 1)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, found := c.Get("a-reinserted"); found {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("callback re-entry into the cache never completed")
+}
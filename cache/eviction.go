@@ -0,0 +1,228 @@
+package cache
+
+import "container/list"
+
+// EvictionPolicy selects how a capacity-bounded cache chooses which item to
+// remove when a Set would push it past MaxEntries.
+type EvictionPolicy int
+
+const (
+	// PolicyNone is the zero value. On its own it disables capacity-based
+	// eviction, but NewTypedWithOptions upgrades it to PolicyLRU whenever
+	// Options.MaxEntries is positive, since MaxEntries with no policy
+	// would otherwise silently grow the cache unbounded. To leave a cache
+	// truly unbounded, leave MaxEntries at zero instead.
+	PolicyNone EvictionPolicy = iota
+	// PolicyLRU evicts the least recently used item (by Get or Set).
+	PolicyLRU
+	// PolicyLFU evicts the least frequently used item, breaking ties in
+	// favor of the one that has gone longest without a repeat access.
+	PolicyLFU
+	// PolicyFIFO evicts the item that was inserted first, regardless of
+	// how often or recently it has been accessed.
+	PolicyFIFO
+)
+
+// evictionTracker maintains the bookkeeping needed to pick a victim when a
+// capacity-bounded cache must evict an item to make room for a new one.
+// Implementations are not safe for concurrent use; callers must hold the
+// cache's write lock.
+type evictionTracker[K comparable] interface {
+	// add records a newly inserted key.
+	add(key K)
+	// touch records an access (Get) or update (Set on an existing key).
+	touch(key K)
+	// remove forgets a key that left the cache via Delete, expiration, or
+	// capacity eviction. Clear replaces the tracker instead of calling remove.
+	remove(key K)
+	// victim returns the key that should be evicted next, if any.
+	victim() (K, bool)
+}
+
+func newEvictionTracker[K comparable](policy EvictionPolicy) evictionTracker[K] {
+	switch policy {
+	case PolicyLRU:
+		return newLRUTracker[K]()
+	case PolicyLFU:
+		return newLFUTracker[K]()
+	case PolicyFIFO:
+		return newFIFOTracker[K]()
+	default:
+		return noopTracker[K]{}
+	}
+}
+
+// noopTracker is used when capacity eviction is disabled, so Get/Set avoid
+// any bookkeeping overhead.
+type noopTracker[K comparable] struct{}
+
+func (noopTracker[K]) add(K)    {}
+func (noopTracker[K]) touch(K)  {}
+func (noopTracker[K]) remove(K) {}
+func (noopTracker[K]) victim() (k K, ok bool) {
+	return k, false
+}
+
+// lruTracker evicts the least recently used key. The list's front holds the
+// most recently used key, its back the least recently used.
+type lruTracker[K comparable] struct {
+	order *list.List
+	elem  map[K]*list.Element
+}
+
+func newLRUTracker[K comparable]() *lruTracker[K] {
+	return &lruTracker[K]{order: list.New(), elem: make(map[K]*list.Element)}
+}
+
+func (t *lruTracker[K]) add(key K) {
+	t.elem[key] = t.order.PushFront(key)
+}
+
+func (t *lruTracker[K]) touch(key K) {
+	if e, ok := t.elem[key]; ok {
+		t.order.MoveToFront(e)
+	}
+}
+
+func (t *lruTracker[K]) remove(key K) {
+	if e, ok := t.elem[key]; ok {
+		t.order.Remove(e)
+		delete(t.elem, key)
+	}
+}
+
+func (t *lruTracker[K]) victim() (K, bool) {
+	e := t.order.Back()
+	if e == nil {
+		var zero K
+		return zero, false
+	}
+	return e.Value.(K), true
+}
+
+// fifoTracker evicts the key that was inserted first; touch is a no-op
+// since FIFO ignores access patterns entirely.
+type fifoTracker[K comparable] struct {
+	order *list.List
+	elem  map[K]*list.Element
+}
+
+func newFIFOTracker[K comparable]() *fifoTracker[K] {
+	return &fifoTracker[K]{order: list.New(), elem: make(map[K]*list.Element)}
+}
+
+func (t *fifoTracker[K]) add(key K) {
+	t.elem[key] = t.order.PushBack(key)
+}
+
+func (t *fifoTracker[K]) touch(K) {}
+
+func (t *fifoTracker[K]) remove(key K) {
+	if e, ok := t.elem[key]; ok {
+		t.order.Remove(e)
+		delete(t.elem, key)
+	}
+}
+
+func (t *fifoTracker[K]) victim() (K, bool) {
+	e := t.order.Front()
+	if e == nil {
+		var zero K
+		return zero, false
+	}
+	return e.Value.(K), true
+}
+
+// lfuTracker evicts the least frequently used key, using the classic O(1)
+// LFU scheme: keys are grouped into per-frequency buckets, and minFreq
+// tracks the smallest frequency with at least one key so the next victim is
+// the front of buckets[minFreq]. add and touch keep minFreq current; remove
+// does not, so victim rescans the buckets when minFreq's bucket is gone.
+type lfuTracker[K comparable] struct {
+	freq    map[K]int
+	buckets map[int]*list.List
+	elem    map[K]*list.Element
+	minFreq int
+}
+
+func newLFUTracker[K comparable]() *lfuTracker[K] {
+	return &lfuTracker[K]{
+		freq:    make(map[K]int),
+		buckets: make(map[int]*list.List),
+		elem:    make(map[K]*list.Element),
+	}
+}
+
+func (t *lfuTracker[K]) add(key K) {
+	t.freq[key] = 1
+	t.pushToBucket(key, 1)
+	t.minFreq = 1
+}
+
+func (t *lfuTracker[K]) touch(key K) {
+	f, ok := t.freq[key]
+	if !ok {
+		return
+	}
+	t.removeFromBucket(key, f)
+	t.freq[key] = f + 1
+	t.pushToBucket(key, f+1)
+	if t.minFreq == f {
+		if b, ok := t.buckets[f]; !ok || b.Len() == 0 {
+			t.minFreq = f + 1
+		}
+	}
+}
+
+func (t *lfuTracker[K]) remove(key K) {
+	f, ok := t.freq[key]
+	if !ok {
+		return
+	}
+	t.removeFromBucket(key, f)
+	delete(t.freq, key)
+}
+
+func (t *lfuTracker[K]) victim() (K, bool) {
+	b, ok := t.buckets[t.minFreq]
+	if !ok || b.Len() == 0 {
+		// minFreq is stale because remove emptied its bucket; fall back
+		// to scanning for the smallest non-empty bucket.
+		found := false
+		for f, bucket := range t.buckets {
+			if bucket.Len() > 0 && (!found || f < t.minFreq) {
+				t.minFreq = f
+				found = true
+			}
+		}
+		if !found {
+			var zero K
+			return zero, false
+		}
+		b = t.buckets[t.minFreq]
+	}
+	return b.Front().Value.(K), true
+}
+
+func (t *lfuTracker[K]) pushToBucket(key K, freq int) {
+	b, ok := t.buckets[freq]
+	if !ok {
+		b = list.New()
+		t.buckets[freq] = b
+	}
+	t.elem[key] = b.PushBack(key)
+}
+
+func (t *lfuTracker[K]) removeFromBucket(key K, freq int) {
+	b, ok := t.buckets[freq]
+	if !ok {
+		return
+	}
+	if e, ok := t.elem[key]; ok {
+		b.Remove(e)
+		delete(t.elem, key)
+	}
+	if b.Len() == 0 {
+		delete(t.buckets, freq)
+	}
+}
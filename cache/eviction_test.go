@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+// TestLRUEvictionUnderCapacity verifies that once MaxEntries is reached, the
+// least recently used key is the one evicted, and that Get counts as a use.
+func TestLRUEvictionUnderCapacity(t *testing.T) {
+	c := NewTypedWithOptions[string, int](time.Hour, Options{MaxEntries: 2, EvictionPolicy: PolicyLRU})
+	defer c.Close()
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a") // "a" is now more recently used than "b"
+	c.Set("c", 3)
+
+	if _, found := c.Get("b"); found {
+		t.Fatal("expected b to be evicted as the least recently used key")
+	}
+	if _, found := c.Get("a"); !found {
+		t.Fatal("expected a to survive eviction")
+	}
+	if _, found := c.Get("c"); !found {
+		t.Fatal("expected c to survive as the most recently inserted key")
+	}
+	if got := c.Count(); got != 2 {
+		t.Fatalf("Count() = %d, want 2", got)
+	}
+}
+
+// TestFIFOEvictionUnderCapacity verifies that FIFO evicts the oldest
+// inserted key regardless of how recently it was accessed.
+func TestFIFOEvictionUnderCapacity(t *testing.T) {
+	c := NewTypedWithOptions[string, int](time.Hour, Options{MaxEntries: 2, EvictionPolicy: PolicyFIFO})
+	defer c.Close()
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a") // FIFO ignores access patterns; "a" is still the oldest insert
+	c.Set("c", 3)
+
+	if _, found := c.Get("a"); found {
+		t.Fatal("expected a to be evicted as the first-inserted key")
+	}
+	if _, found := c.Get("b"); !found {
+		t.Fatal("expected b to survive eviction")
+	}
+}
+
+// TestLFUEvictionUnderCapacity verifies that LFU evicts the least
+// frequently accessed key, breaking ties among equally-infrequent keys in
+// favor of the one that has gone longest without a repeat access.
+func TestLFUEvictionUnderCapacity(t *testing.T) {
+	c := NewTypedWithOptions[string, int](time.Hour, Options{MaxEntries: 3, EvictionPolicy: PolicyLFU})
+	defer c.Close()
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3) // never touched again; lowest frequency and oldest at that frequency
+	c.Get("a")
+	c.Get("b")
+	c.Set("d", 4)
+
+	if _, found := c.Get("c"); found {
+		t.Fatal("expected c to be evicted as the least frequently used, longest-untouched key")
+	}
+	for _, key := range []string{"a", "b", "d"} {
+		if _, found := c.Get(key); !found {
+			t.Fatalf("expected %q to survive eviction", key)
+		}
+	}
+}
+
+// TestCapacityEvictionFiresOnEvictedWithReason verifies that a capacity
+// eviction is reported to OnEvicted with EvictCapacity, distinguishing it
+// from expiration/deletion/replace/clear.
+func TestCapacityEvictionFiresOnEvictedWithReason(t *testing.T) {
+	c := NewTypedWithOptions[string, int](time.Hour, Options{MaxEntries: 1, EvictionPolicy: PolicyFIFO})
+	defer c.Close()
+
+	var gotKey string
+	var gotReason EvictReason
+	c.OnEvicted(func(key string, value int, reason EvictReason) {
+		gotKey = key
+		gotReason = reason
+	})
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	if gotKey != "a" {
+		t.Fatalf("evicted key = %q, want %q", gotKey, "a")
+	}
+	if gotReason != EvictCapacity {
+		t.Fatalf("evicted reason = %v, want %v", gotReason, EvictCapacity)
+	}
+}
+
+// TestCapacityEvictionDoesNotExceedMaxEntries inserts well past MaxEntries
+// and verifies the cache never grows beyond it.
+func TestCapacityEvictionDoesNotExceedMaxEntries(t *testing.T) {
+	const max = 5
+	c := NewTypedWithOptions[int, int](time.Hour, Options{MaxEntries: max, EvictionPolicy: PolicyLRU})
+	defer c.Close()
+
+	for i := 0; i < 100; i++ {
+		c.Set(i, i)
+		if got := c.Len(); got > max {
+			t.Fatalf("Len() = %d after inserting key %d, want <= %d", got, i, max)
+		}
+	}
+}
+
+// TestMaxEntriesWithoutPolicyDefaultsToLRU verifies that leaving
+// EvictionPolicy at its zero value (PolicyNone) while setting a positive
+// MaxEntries does not leave the cache unbounded: NewTypedWithOptions
+// upgrades it to PolicyLRU instead, since PolicyNone plus a MaxEntries cap
+// has no sensible meaning.
+func TestMaxEntriesWithoutPolicyDefaultsToLRU(t *testing.T) {
+	c := NewTypedWithOptions[int, int](time.Hour, Options{MaxEntries: 2})
+	defer c.Close()
+
+	for i := 0; i < 5; i++ {
+		c.Set(i, i)
+	}
+	if got := c.Len(); got != 2 {
+		t.Fatalf("Len() = %d, want 2 (MaxEntries with no explicit policy should default to PolicyLRU)", got)
+	}
+	// The two most recently set keys (3, 4) should be the survivors under LRU.
+	for _, key := range []int{3, 4} {
+		if _, found := c.Get(key); !found {
+			t.Fatalf("key %d missing, want it retained by the LRU default", key)
+		}
+	}
+}
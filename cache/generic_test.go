@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+// TestTypedNonStringKeyNonInterfaceValue exercises Typed with a key/value
+// pair distinct from both Cache's string keys and its interface{} values,
+// to prove the generics don't secretly rely on either.
+func TestTypedNonStringKeyNonInterfaceValue(t *testing.T) {
+	c := NewTyped[int, string](time.Hour)
+	defer c.Close()
+
+	c.Set(1, "one")
+	c.Set(2, "two")
+
+	got, found := c.Get(1)
+	if !found {
+		t.Fatal("Get(1) not found")
+	}
+	if got != "one" {
+		t.Fatalf("Get(1) = %q, want %q", got, "one")
+	}
+
+	if _, found := c.Get(3); found {
+		t.Fatal("Get(3) unexpectedly found")
+	}
+}
+
+// TestTypedGetReturnsZeroValueOnMiss verifies Get's zero-value contract
+// holds for a non-trivial value type, not just int/string.
+func TestTypedGetReturnsZeroValueOnMiss(t *testing.T) {
+	type point struct{ X, Y int }
+
+	c := NewTyped[string, point](time.Hour)
+	defer c.Close()
+
+	got, found := c.Get("missing")
+	if found {
+		t.Fatal("Get unexpectedly found a value for a missing key")
+	}
+	if got != (point{}) {
+		t.Fatalf("Get on miss = %+v, want zero value", got)
+	}
+}
+
+// TestCacheInheritsTypedMethodsViaEmbedding verifies that Cache, a thin
+// wrapper around Typed[string, interface{}], gets Typed's methods for
+// free through embedding rather than needing its own copies.
+func TestCacheInheritsTypedMethodsViaEmbedding(t *testing.T) {
+	c := New(time.Hour)
+	defer c.Close()
+
+	c.Set("a", 1)
+	if got, found := c.Get("a"); !found || got != 1 {
+		t.Fatalf("Get(a) = %v, %v; want 1, true", got, found)
+	}
+
+	if err := c.Add("a", 2, time.Hour); err == nil {
+		t.Fatal("Add on an existing key should fail")
+	}
+
+	stats := c.Stats()
+	if stats.Size != 1 {
+		t.Fatalf("Stats().Size = %d, want 1", stats.Size)
+	}
+}
+
+// TestTypedDeleteAndClear exercises the basic mutation surface against a
+// generic instantiation, independent of Cache's interface{} boxing.
+func TestTypedDeleteAndClear(t *testing.T) {
+	c := NewTyped[int, int](time.Hour)
+	defer c.Close()
+
+	for i := 0; i < 3; i++ {
+		c.Set(i, i*i)
+	}
+	c.Delete(1)
+	if _, found := c.Get(1); found {
+		t.Fatal("Get(1) found after Delete")
+	}
+	if got := c.Count(); got != 2 {
+		t.Fatalf("Count() = %d, want 2", got)
+	}
+
+	c.Clear()
+	if got := c.Count(); got != 0 {
+		t.Fatalf("Count() after Clear = %d, want 0", got)
+	}
+}
@@ -0,0 +1,34 @@
+package cache
+
+// priorityQueue is a container/heap of cache items ordered by expiration,
+// soonest-expiring first. It backs the expiration goroutine so that it can
+// sleep exactly until the next item is due instead of polling the map.
+type priorityQueue[K comparable, V any] []*item[K, V]
+
+func (pq priorityQueue[K, V]) Len() int { return len(pq) }
+
+func (pq priorityQueue[K, V]) Less(i, j int) bool {
+	return pq[i].expiration.Before(pq[j].expiration)
+}
+
+func (pq priorityQueue[K, V]) Swap(i, j int) {
+	pq[i], pq[j] = pq[j], pq[i]
+	pq[i].heapIndex = i
+	pq[j].heapIndex = j
+}
+
+func (pq *priorityQueue[K, V]) Push(x interface{}) {
+	it := x.(*item[K, V])
+	it.heapIndex = len(*pq)
+	*pq = append(*pq, it)
+}
+
+func (pq *priorityQueue[K, V]) Pop() interface{} {
+	old := *pq
+	n := len(old)
+	it := old[n-1]
+	old[n-1] = nil
+	it.heapIndex = -1
+	*pq = old[:n-1]
+	return it
+}
@@ -0,0 +1,153 @@
+package cache
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrKeyExists is returned by Add when key is already present with an
+// unexpired value.
+var ErrKeyExists = errors.New("cache: key already exists")
+
+// ErrKeyMissing is returned by Replace when key is not present, or is
+// present but expired.
+var ErrKeyMissing = errors.New("cache: key missing")
+
+// Add stores value under key only if key is not already present with an
+// unexpired value. It returns ErrKeyExists otherwise.
+func (c *Typed[K, V]) Add(key K, value V, ttl time.Duration) error {
+	c.mu.Lock()
+	if old, found := c.items[key]; found && time.Now().Before(old.expiration) {
+		c.mu.Unlock()
+		return ErrKeyExists
+	}
+	result := c.setLocked(key, value, ttl)
+	c.mu.Unlock()
+
+	c.fireSetResult(key, value, result)
+	return nil
+}
+
+// Replace stores value under key only if key is already present with an
+// unexpired value. It returns ErrKeyMissing otherwise.
+func (c *Typed[K, V]) Replace(key K, value V, ttl time.Duration) error {
+	c.mu.Lock()
+	old, found := c.items[key]
+	if !found || time.Now().After(old.expiration) {
+		c.mu.Unlock()
+		return ErrKeyMissing
+	}
+	result := c.setLocked(key, value, ttl)
+	c.mu.Unlock()
+
+	c.fireSetResult(key, value, result)
+	return nil
+}
+
+// GetOrSet returns the existing unexpired value for key if present.
+// Otherwise it calls valueFn, stores the result with ttl, and returns it.
+//
+// valueFn runs under the cache's write lock, so concurrent callers for the
+// same key coalesce onto a single call to valueFn instead of racing to
+// compute and overwrite the value - avoiding a thundering herd of
+// recomputation for expensive producers.
+func (c *Typed[K, V]) GetOrSet(key K, valueFn func() (V, error), ttl time.Duration) (V, error) {
+	c.mu.Lock()
+
+	if old, found := c.items[key]; found && time.Now().Before(old.expiration) {
+		value := old.value
+		if !c.skipTTLExtension {
+			old.touch(c.ttl)
+			heap.Fix(&c.pq, old.heapIndex)
+			c.wakeIfEarlier(old.expiration)
+		}
+		if c.maxEntries > 0 {
+			c.tracker.touch(key)
+		}
+		c.mu.Unlock()
+		return value, nil
+	}
+
+	value, err := valueFn()
+	if err != nil {
+		c.mu.Unlock()
+		var zero V
+		return zero, err
+	}
+
+	result := c.setLocked(key, value, ttl)
+	c.mu.Unlock()
+
+	c.fireSetResult(key, value, result)
+	return value, nil
+}
+
+// IncrementInt64 adds delta to the int64 stored at key and returns the new
+// value. If key is absent or expired, it starts from 0. The read, modify,
+// and write happen under a single lock acquisition so concurrent increments
+// are not lost. It returns an error if key holds a value that is not an
+// int64, or if the cache's value type cannot hold an int64.
+func (c *Typed[K, V]) IncrementInt64(key K, delta int64) (int64, error) {
+	c.mu.Lock()
+
+	var current int64
+	if old, found := c.items[key]; found && time.Now().Before(old.expiration) {
+		v, ok := any(old.value).(int64)
+		if !ok {
+			c.mu.Unlock()
+			return 0, fmt.Errorf("cache: value for key %v is %T, not int64", key, old.value)
+		}
+		current = v
+	}
+
+	next := current + delta
+	value, ok := any(next).(V)
+	if !ok {
+		c.mu.Unlock()
+		return 0, fmt.Errorf("cache: cannot store int64 in this cache's value type")
+	}
+	result := c.setLocked(key, value, c.ttl)
+	c.mu.Unlock()
+
+	c.fireSetResult(key, value, result)
+	return next, nil
+}
+
+// DecrementInt64 subtracts delta from the int64 stored at key. It is
+// equivalent to IncrementInt64(key, -delta).
+func (c *Typed[K, V]) DecrementInt64(key K, delta int64) (int64, error) {
+	return c.IncrementInt64(key, -delta)
+}
+
+// IncrementFloat64 adds delta to the float64 stored at key and returns the
+// new value. If key is absent or expired, it starts from 0. The read,
+// modify, and write happen under a single lock acquisition so concurrent
+// increments are not lost. It returns an error if key holds a value that is
+// not a float64, or if the cache's value type cannot hold a float64.
+func (c *Typed[K, V]) IncrementFloat64(key K, delta float64) (float64, error) {
+	c.mu.Lock()
+
+	var current float64
+	if old, found := c.items[key]; found && time.Now().Before(old.expiration) {
+		v, ok := any(old.value).(float64)
+		if !ok {
+			c.mu.Unlock()
+			return 0, fmt.Errorf("cache: value for key %v is %T, not float64", key, old.value)
+		}
+		current = v
+	}
+
+	next := current + delta
+	value, ok := any(next).(V)
+	if !ok {
+		c.mu.Unlock()
+		return 0, fmt.Errorf("cache: cannot store float64 in this cache's value type")
+	}
+	result := c.setLocked(key, value, c.ttl)
+	c.mu.Unlock()
+
+	c.fireSetResult(key, value, result)
+	return next, nil
+}
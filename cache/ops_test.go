@@ -0,0 +1,117 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAdd(t *testing.T) {
+	c := New(time.Hour)
+	defer c.Close()
+
+	if err := c.Add("a", 1, time.Hour); err != nil {
+		t.Fatalf("Add on empty key: %v", err)
+	}
+	if err := c.Add("a", 2, time.Hour); !errors.Is(err, ErrKeyExists) {
+		t.Fatalf("Add on existing key: err = %v, want ErrKeyExists", err)
+	}
+	if v, _ := c.Get("a"); v != 1 {
+		t.Fatalf("Get(a) = %v, want 1 (second Add should not have overwritten it)", v)
+	}
+}
+
+func TestReplace(t *testing.T) {
+	c := New(time.Hour)
+	defer c.Close()
+
+	if err := c.Replace("a", 1, time.Hour); !errors.Is(err, ErrKeyMissing) {
+		t.Fatalf("Replace on missing key: err = %v, want ErrKeyMissing", err)
+	}
+
+	c.Set("a", 1)
+	if err := c.Replace("a", 2, time.Hour); err != nil {
+		t.Fatalf("Replace on existing key: %v", err)
+	}
+	if v, _ := c.Get("a"); v != 2 {
+		t.Fatalf("Get(a) = %v, want 2", v)
+	}
+}
+
+func TestGetOrSetCoalescesOnHit(t *testing.T) {
+	c := New(time.Hour)
+	defer c.Close()
+
+	calls := 0
+	valueFn := func() (interface{}, error) {
+		calls++
+		return "computed", nil
+	}
+
+	v1, err := c.GetOrSet("a", valueFn, time.Hour)
+	if err != nil {
+		t.Fatalf("GetOrSet (miss): %v", err)
+	}
+	v2, err := c.GetOrSet("a", valueFn, time.Hour)
+	if err != nil {
+		t.Fatalf("GetOrSet (hit): %v", err)
+	}
+
+	if v1 != "computed" || v2 != "computed" {
+		t.Fatalf("GetOrSet values = %v, %v, want both %q", v1, v2, "computed")
+	}
+	if calls != 1 {
+		t.Fatalf("valueFn called %d times, want 1", calls)
+	}
+}
+
+func TestGetOrSetPropagatesError(t *testing.T) {
+	c := New(time.Hour)
+	defer c.Close()
+
+	wantErr := errors.New("boom")
+	_, err := c.GetOrSet("a", func() (interface{}, error) { return nil, wantErr }, time.Hour)
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("GetOrSet error = %v, want %v", err, wantErr)
+	}
+	if _, found := c.Get("a"); found {
+		t.Fatal("expected no value to be stored after valueFn error")
+	}
+}
+
+func TestIncrementDecrementInt64(t *testing.T) {
+	c := New(time.Hour)
+	defer c.Close()
+
+	if v, err := c.IncrementInt64("counter", 5); err != nil || v != 5 {
+		t.Fatalf("IncrementInt64 = %v, %v, want 5, nil", v, err)
+	}
+	if v, err := c.IncrementInt64("counter", 3); err != nil || v != 8 {
+		t.Fatalf("IncrementInt64 = %v, %v, want 8, nil", v, err)
+	}
+	if v, err := c.DecrementInt64("counter", 2); err != nil || v != 6 {
+		t.Fatalf("DecrementInt64 = %v, %v, want 6, nil", v, err)
+	}
+
+	c.Set("not-a-number", "oops")
+	if _, err := c.IncrementInt64("not-a-number", 1); err == nil {
+		t.Fatal("expected error incrementing a non-int64 value")
+	}
+}
+
+func TestIncrementFloat64(t *testing.T) {
+	c := New(time.Hour)
+	defer c.Close()
+
+	if v, err := c.IncrementFloat64("counter", 1.5); err != nil || v != 1.5 {
+		t.Fatalf("IncrementFloat64 = %v, %v, want 1.5, nil", v, err)
+	}
+	if v, err := c.IncrementFloat64("counter", 2.5); err != nil || v != 4.0 {
+		t.Fatalf("IncrementFloat64 = %v, %v, want 4.0, nil", v, err)
+	}
+
+	c.Set("not-a-number", "oops")
+	if _, err := c.IncrementFloat64("not-a-number", 1); err == nil {
+		t.Fatal("expected error incrementing a non-float64 value")
+	}
+}
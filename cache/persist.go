@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"encoding/gob"
+	"io"
+	"os"
+	"time"
+)
+
+// persistedItem is the on-disk representation of a single cache entry.
+// Expiration is stored as an absolute time so that Load can restore items
+// without resetting their remaining TTL.
+type persistedItem[K comparable, V any] struct {
+	Key        K
+	Value      V
+	Expiration time.Time
+}
+
+// Save writes the cache's contents to w using encoding/gob, so they can be
+// restored later with Load. If V is an interface type (as with Cache),
+// callers must gob.Register any concrete value types stored in the cache
+// before calling Save or Load.
+//
+// Save takes the read lock for the duration of the encode.
+func (c *Typed[K, V]) Save(w io.Writer) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	items := make([]persistedItem[K, V], 0, len(c.items))
+	for key, it := range c.items {
+		items = append(items, persistedItem[K, V]{
+			Key:        key,
+			Value:      it.value,
+			Expiration: it.expiration,
+		})
+	}
+	return gob.NewEncoder(w).Encode(items)
+}
+
+// SaveFile is a convenience wrapper around Save that writes to the file at path.
+func (c *Typed[K, V]) SaveFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return c.Save(f)
+}
+
+// Load restores cache contents previously written by Save. Items that have
+// already expired are skipped; all others keep their original expiration
+// rather than being reset to ttl from now.
+//
+// Each item is inserted through the same path as Set, so a key already
+// present in the cache is overwritten in place instead of leaving behind an
+// orphaned heap entry, and the eviction tracker and capacity cap (see
+// Options.MaxEntries) are honored exactly as they would be for a live Set.
+// Load takes the write lock once per item rather than for the whole decode,
+// so OnEvicted/OnNewItem callbacks can run outside the lock like everywhere
+// else in the cache.
+func (c *Typed[K, V]) Load(r io.Reader) error {
+	var items []persistedItem[K, V]
+	if err := gob.NewDecoder(r).Decode(&items); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, pi := range items {
+		if now.After(pi.Expiration) {
+			continue
+		}
+
+		c.mu.Lock()
+		result := c.setAbsoluteLocked(pi.Key, pi.Value, pi.Expiration)
+		c.mu.Unlock()
+
+		c.fireSetResult(pi.Key, pi.Value, result)
+	}
+	return nil
+}
+
+// LoadFile is a convenience wrapper around Load that reads from the file at path.
+func (c *Typed[K, V]) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return c.Load(f)
+}
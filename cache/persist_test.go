@@ -0,0 +1,115 @@
+package cache
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	src := NewTyped[string, int](time.Hour)
+	defer src.Close()
+
+	src.Set("a", 1)
+	src.Set("b", 2)
+	src.SetWithTTL("c", 3, 30*time.Second)
+
+	var buf bytes.Buffer
+	if err := src.Save(&buf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	dst := NewTyped[string, int](time.Hour)
+	defer dst.Close()
+
+	if err := dst.Load(&buf); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	for key, want := range map[string]int{"a": 1, "b": 2, "c": 3} {
+		got, found := dst.Get(key)
+		if !found {
+			t.Fatalf("key %q missing after Load", key)
+		}
+		if got != want {
+			t.Fatalf("Get(%q) = %d, want %d", key, got, want)
+		}
+	}
+}
+
+func TestLoadSkipsAlreadyExpiredItems(t *testing.T) {
+	src := NewTyped[string, int](time.Hour)
+	defer src.Close()
+
+	src.SetWithTTL("live", 1, time.Hour)
+	src.SetWithTTL("dead", 2, -time.Second) // already expired at Save time
+
+	var buf bytes.Buffer
+	if err := src.Save(&buf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	dst := NewTyped[string, int](time.Hour)
+	defer dst.Close()
+
+	if err := dst.Load(&buf); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if _, found := dst.Get("dead"); found {
+		t.Fatal("expected already-expired item to be skipped by Load")
+	}
+	if _, found := dst.Get("live"); !found {
+		t.Fatal("expected live item to survive Load")
+	}
+}
+
+func TestLoadOverwritesExistingKeyInPlace(t *testing.T) {
+	c := NewTyped[string, int](time.Hour)
+	defer c.Close()
+
+	c.Set("a", 1)
+
+	var buf bytes.Buffer
+	src := NewTyped[string, int](time.Hour)
+	defer src.Close()
+	src.Set("a", 99)
+	if err := src.Save(&buf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	if err := c.Load(&buf); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if got, _ := c.Get("a"); got != 99 {
+		t.Fatalf("Get(a) = %d, want 99 (Load should overwrite in place)", got)
+	}
+	if got := c.Count(); got != 1 {
+		t.Fatalf("Count() = %d, want 1 (no orphaned heap entry for the old value)", got)
+	}
+}
+
+func TestLoadHonorsMaxEntries(t *testing.T) {
+	src := NewTyped[string, int](time.Hour)
+	defer src.Close()
+	for i := 0; i < 5; i++ {
+		src.Set(string(rune('a'+i)), i)
+	}
+
+	var buf bytes.Buffer
+	if err := src.Save(&buf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	dst := NewTypedWithOptions[string, int](time.Hour, Options{MaxEntries: 2, EvictionPolicy: PolicyFIFO})
+	defer dst.Close()
+
+	if err := dst.Load(&buf); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if got := dst.Len(); got != 2 {
+		t.Fatalf("Len() = %d after Load, want 2 (MaxEntries not honored by Load)", got)
+	}
+}
@@ -0,0 +1,101 @@
+// Package promcache adapts cache.Stats to Prometheus metrics.
+//
+// Example usage:
+//
+//	c := cache.New(5 * time.Minute)
+//	adapter := promcache.New(prometheus.DefaultRegisterer, "api_cache")
+//	go func() {
+//		for range time.Tick(10 * time.Second) {
+//			c.ReportTo(adapter)
+//		}
+//	}()
+package promcache
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/acp-protocol/acp-spec/cache"
+)
+
+// Adapter implements cache.MetricsCollector, translating each Stats
+// snapshot into a set of Prometheus gauges and counters.
+type Adapter struct {
+	mu sync.Mutex
+
+	lastHits        uint64
+	lastMisses      uint64
+	lastEvictions   uint64
+	lastExpirations uint64
+	lastInsertions  uint64
+
+	hits        prometheus.Counter
+	misses      prometheus.Counter
+	evictions   prometheus.Counter
+	expirations prometheus.Counter
+	insertions  prometheus.Counter
+	size        prometheus.Gauge
+	hitRatio    prometheus.Gauge
+}
+
+// New creates an Adapter and registers its metrics with reg, prefixed by
+// name (e.g. name "api_cache" produces api_cache_hits_total, api_cache_size,
+// and so on).
+func New(reg prometheus.Registerer, name string) *Adapter {
+	a := &Adapter{
+		hits: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: name + "_hits_total",
+			Help: "Total number of cache Get calls that found an unexpired value.",
+		}),
+		misses: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: name + "_misses_total",
+			Help: "Total number of cache Get calls that found no unexpired value.",
+		}),
+		evictions: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: name + "_evictions_total",
+			Help: "Total number of items removed to stay within MaxEntries.",
+		}),
+		expirations: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: name + "_expirations_total",
+			Help: "Total number of items removed because their TTL elapsed.",
+		}),
+		insertions: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: name + "_insertions_total",
+			Help: "Total number of new keys inserted into the cache.",
+		}),
+		size: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: name + "_size",
+			Help: "Current number of items in the cache.",
+		}),
+		hitRatio: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: name + "_hit_ratio",
+			Help: "Cache hit ratio (hits / (hits + misses)) since the cache was created.",
+		}),
+	}
+	reg.MustRegister(a.hits, a.misses, a.evictions, a.expirations, a.insertions, a.size, a.hitRatio)
+	return a
+}
+
+// Collect implements cache.MetricsCollector. Stats' counters are
+// cumulative, but Prometheus counters may only increase via Add, so Collect
+// tracks the last-seen value of each and reports the delta.
+func (a *Adapter) Collect(stats cache.Stats) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.hits.Add(float64(stats.Hits - a.lastHits))
+	a.misses.Add(float64(stats.Misses - a.lastMisses))
+	a.evictions.Add(float64(stats.Evictions - a.lastEvictions))
+	a.expirations.Add(float64(stats.Expirations - a.lastExpirations))
+	a.insertions.Add(float64(stats.Insertions - a.lastInsertions))
+
+	a.lastHits = stats.Hits
+	a.lastMisses = stats.Misses
+	a.lastEvictions = stats.Evictions
+	a.lastExpirations = stats.Expirations
+	a.lastInsertions = stats.Insertions
+
+	a.size.Set(float64(stats.Size))
+	a.hitRatio.Set(stats.HitRatio)
+}
@@ -0,0 +1,77 @@
+package promcache
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/acp-protocol/acp-spec/cache"
+)
+
+func TestCollectSetsGaugesFromFirstSnapshot(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	a := New(reg, "test_cache")
+
+	a.Collect(cache.Stats{
+		Hits:        3,
+		Misses:      1,
+		Evictions:   0,
+		Expirations: 0,
+		Insertions:  4,
+		Size:        4,
+		HitRatio:    0.75,
+	})
+
+	if got := testutil.ToFloat64(a.hits); got != 3 {
+		t.Fatalf("hits = %v, want 3", got)
+	}
+	if got := testutil.ToFloat64(a.insertions); got != 4 {
+		t.Fatalf("insertions = %v, want 4", got)
+	}
+	if got := testutil.ToFloat64(a.size); got != 4 {
+		t.Fatalf("size = %v, want 4", got)
+	}
+	if got := testutil.ToFloat64(a.hitRatio); got != 0.75 {
+		t.Fatalf("hitRatio = %v, want 0.75", got)
+	}
+}
+
+func TestCollectReportsDeltasNotCumulativeValues(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	a := New(reg, "test_cache")
+
+	a.Collect(cache.Stats{Hits: 3, Insertions: 4, Size: 4})
+	a.Collect(cache.Stats{Hits: 5, Insertions: 4, Size: 4}) // +2 hits, +0 insertions
+
+	if got := testutil.ToFloat64(a.hits); got != 5 {
+		t.Fatalf("hits counter total = %v, want 5 (3 + 2 delta)", got)
+	}
+	if got := testutil.ToFloat64(a.insertions); got != 4 {
+		t.Fatalf("insertions counter total = %v, want 4 (no delta on second Collect)", got)
+	}
+}
+
+func TestNewRegistersMetricsUnderGivenName(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	New(reg, "api_cache")
+
+	mfs, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+
+	var sawSize bool
+	for _, mf := range mfs {
+		if mf.GetName() == "api_cache_size" {
+			sawSize = true
+		}
+		if !strings.HasPrefix(mf.GetName(), "api_cache_") {
+			t.Fatalf("metric %q not prefixed with api_cache_", mf.GetName())
+		}
+	}
+	if !sawSize {
+		t.Fatal("expected api_cache_size to be registered")
+	}
+}
@@ -0,0 +1,36 @@
+package cache
+
+// EvictReason describes why an item was removed from the cache.
+type EvictReason int
+
+const (
+	// EvictExpired indicates the item was removed because its TTL elapsed.
+	EvictExpired EvictReason = iota
+	// EvictDeleted indicates the item was removed by an explicit Delete call.
+	EvictDeleted
+	// EvictReplaced indicates the item was overwritten by a new value under the same key.
+	EvictReplaced
+	// EvictCleared indicates the item was removed as part of a Clear call.
+	EvictCleared
+	// EvictCapacity indicates the item was removed to keep the cache within
+	// MaxEntries, per the configured EvictionPolicy.
+	EvictCapacity
+)
+
+// String returns a human-readable name for the eviction reason.
+func (r EvictReason) String() string {
+	switch r {
+	case EvictExpired:
+		return "expired"
+	case EvictDeleted:
+		return "deleted"
+	case EvictReplaced:
+		return "replaced"
+	case EvictCleared:
+		return "cleared"
+	case EvictCapacity:
+		return "capacity"
+	default:
+		return "unknown"
+	}
+}
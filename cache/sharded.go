@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"hash/fnv"
+	"runtime"
+	"time"
+)
+
+// Sharded partitions keys across N independent Cache shards so that
+// high-QPS callers no longer serialize on a single sync.RWMutex. Keys are
+// routed to shards by fnv-1a of the key, modulo the shard count.
+//
+// Sharded's public API mirrors Cache; each shard runs its own expiration
+// goroutine.
+type Sharded struct {
+	shards []*Cache
+}
+
+// NewSharded creates a Sharded cache with the specified default TTL,
+// partitioned into shardCount shards. If shardCount is <= 0, it defaults to
+// runtime.GOMAXPROCS(0)*4 rounded up to the next power of two.
+func NewSharded(ttl time.Duration, shardCount int) *Sharded {
+	return NewShardedWithOptions(ttl, shardCount, Options{})
+}
+
+// NewShardedWithOptions creates a Sharded cache like NewSharded, but applies
+// opts to every shard. Note that Options.MaxEntries bounds each shard
+// independently, so the cache's overall capacity is MaxEntries * shardCount,
+// not MaxEntries.
+func NewShardedWithOptions(ttl time.Duration, shardCount int, opts Options) *Sharded {
+	if shardCount <= 0 {
+		shardCount = nextPowerOfTwo(runtime.GOMAXPROCS(0) * 4)
+	}
+
+	s := &Sharded{shards: make([]*Cache, shardCount)}
+	for i := range s.shards {
+		s.shards[i] = NewWithOptions(ttl, opts)
+	}
+	return s
+}
+
+// ShardCount returns the number of shards backing the cache.
+func (s *Sharded) ShardCount() int {
+	return len(s.shards)
+}
+
+// ForEachShard calls fn once for each shard, in shard order. It is intended
+// for observability (e.g. summing per-shard stats), not for mutation.
+func (s *Sharded) ForEachShard(fn func(shard *Cache)) {
+	for _, shard := range s.shards {
+		fn(shard)
+	}
+}
+
+// shardFor returns the shard responsible for key.
+func (s *Sharded) shardFor(key string) *Cache {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return s.shards[h.Sum32()%uint32(len(s.shards))]
+}
+
+// Get retrieves a value from the cache.
+// It returns the value and true if found and not expired,
+// or nil and false otherwise.
+func (s *Sharded) Get(key string) (interface{}, bool) {
+	return s.shardFor(key).Get(key)
+}
+
+// Set stores a value in the cache with the default TTL.
+func (s *Sharded) Set(key string, value interface{}) {
+	s.shardFor(key).Set(key, value)
+}
+
+// SetWithTTL stores a value with a custom TTL.
+func (s *Sharded) SetWithTTL(key string, value interface{}, ttl time.Duration) {
+	s.shardFor(key).SetWithTTL(key, value, ttl)
+}
+
+// Delete removes an item from the cache.
+// It returns true if the item existed, false otherwise.
+func (s *Sharded) Delete(key string) bool {
+	return s.shardFor(key).Delete(key)
+}
+
+// Count returns the number of non-expired items across all shards.
+func (s *Sharded) Count() int {
+	count := 0
+	for _, shard := range s.shards {
+		count += shard.Count()
+	}
+	return count
+}
+
+// Clear removes all items from every shard.
+func (s *Sharded) Clear() {
+	for _, shard := range s.shards {
+		shard.Clear()
+	}
+}
+
+// Close stops every shard's expiration goroutine.
+// After Close is called, the cache should not be used.
+func (s *Sharded) Close() {
+	for _, shard := range s.shards {
+		shard.Close()
+	}
+}
+
+// nextPowerOfTwo returns the smallest power of two >= n, or 1 if n <= 1.
+func nextPowerOfTwo(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+// TestShardedRoutingIsStable verifies that shardFor always routes the same
+// key to the same shard, so Set followed by Get for the same key never
+// misses due to inconsistent hashing.
+func TestShardedRoutingIsStable(t *testing.T) {
+	s := NewSharded(time.Hour, 8)
+	defer s.Close()
+
+	for i := 0; i < 100; i++ {
+		key := strconv.Itoa(i)
+		want := s.shardFor(key)
+		for j := 0; j < 5; j++ {
+			if got := s.shardFor(key); got != want {
+				t.Fatalf("shardFor(%q) is not stable across calls", key)
+			}
+		}
+	}
+}
+
+// TestShardedGetSetRoundTrip verifies values set through the Sharded API can
+// be read back regardless of which shard they land on.
+func TestShardedGetSetRoundTrip(t *testing.T) {
+	s := NewSharded(time.Hour, 4)
+	defer s.Close()
+
+	for i := 0; i < 50; i++ {
+		key := strconv.Itoa(i)
+		s.Set(key, i)
+	}
+	for i := 0; i < 50; i++ {
+		key := strconv.Itoa(i)
+		got, found := s.Get(key)
+		if !found {
+			t.Fatalf("key %q not found", key)
+		}
+		if got != i {
+			t.Fatalf("Get(%q) = %v, want %d", key, got, i)
+		}
+	}
+	if got := s.Count(); got != 50 {
+		t.Fatalf("Count() = %d, want 50", got)
+	}
+}
+
+// TestShardedWithOptionsAppliesToEveryShard verifies that Options passed to
+// NewShardedWithOptions (e.g. MaxEntries) are honored independently by every
+// shard, not just the first.
+func TestShardedWithOptionsAppliesToEveryShard(t *testing.T) {
+	s := NewShardedWithOptions(time.Hour, 4, Options{MaxEntries: 1, EvictionPolicy: PolicyFIFO})
+	defer s.Close()
+
+	s.ForEachShard(func(shard *Cache) {
+		shard.Set("a", 1)
+		shard.Set("b", 2)
+		if got := shard.Len(); got != 1 {
+			t.Fatalf("shard Len() = %d, want 1 (MaxEntries not honored)", got)
+		}
+	})
+}
+
+// TestShardedCloseStopsEveryShard verifies Close tears down every shard's
+// expiration goroutine, not just one, by checking that every shard's done
+// channel, which the goroutine waits on, has been closed.
+func TestShardedCloseStopsEveryShard(t *testing.T) {
+	s := NewSharded(10*time.Millisecond, 4)
+
+	for i := 0; i < 20; i++ {
+		s.Set(strconv.Itoa(i), i)
+	}
+
+	s.Close()
+
+	closed := make(chan struct{})
+	go func() {
+		s.ForEachShard(func(shard *Cache) {
+			<-shard.done
+		})
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("not every shard's expiration goroutine was signaled to stop")
+	}
+}
+
+// TestShardCountDefaultsToPowerOfTwo verifies that requesting a non-positive
+// shard count falls back to the documented power-of-two default instead of
+// producing zero shards.
+func TestShardCountDefaultsToPowerOfTwo(t *testing.T) {
+	s := NewSharded(time.Hour, 0)
+	defer s.Close()
+
+	n := s.ShardCount()
+	if n <= 0 {
+		t.Fatalf("ShardCount() = %d, want > 0", n)
+	}
+	if n&(n-1) != 0 {
+		t.Fatalf("ShardCount() = %d, want a power of two", n)
+	}
+}
@@ -0,0 +1,67 @@
+package cache
+
+import "sync/atomic"
+
+// Stats is a point-in-time snapshot of a cache's hit/miss and
+// eviction counters, as returned by Cache.Stats.
+type Stats struct {
+	// Hits is the number of Get calls that found an unexpired value.
+	Hits uint64
+	// Misses is the number of Get calls that found no value, or an expired one.
+	Misses uint64
+	// Evictions is the number of items removed to stay within MaxEntries.
+	Evictions uint64
+	// Expirations is the number of items removed because their TTL elapsed.
+	Expirations uint64
+	// Insertions is the number of keys that did not previously exist and
+	// were added to the cache.
+	Insertions uint64
+	// Size is the current number of items in the cache, including any not
+	// yet swept that have expired.
+	Size int
+	// HitRatio is Hits / (Hits + Misses), or 0 if there have been no Get calls.
+	HitRatio float64
+}
+
+// MetricsCollector receives cache statistics so they can be exported to an
+// external monitoring system. See the promcache subpackage for a ready-made
+// Prometheus adapter.
+type MetricsCollector interface {
+	// Collect is called with the current Stats snapshot. Implementations
+	// should be cheap, since callers may invoke ReportTo on a tight interval.
+	Collect(stats Stats)
+}
+
+// Stats returns a snapshot of the cache's hit/miss and eviction counters
+// along with its current size.
+func (c *Typed[K, V]) Stats() Stats {
+	hits := atomic.LoadUint64(&c.hits)
+	misses := atomic.LoadUint64(&c.misses)
+
+	var hitRatio float64
+	if total := hits + misses; total > 0 {
+		hitRatio = float64(hits) / float64(total)
+	}
+
+	c.mu.RLock()
+	size := len(c.items)
+	c.mu.RUnlock()
+
+	return Stats{
+		Hits:        hits,
+		Misses:      misses,
+		Evictions:   atomic.LoadUint64(&c.evictions),
+		Expirations: atomic.LoadUint64(&c.expirations),
+		Insertions:  atomic.LoadUint64(&c.insertions),
+		Size:        size,
+		HitRatio:    hitRatio,
+	}
+}
+
+// ReportTo pushes the current Stats snapshot to collector. Callers
+// typically invoke this periodically (e.g. from a time.Ticker) since Stats
+// itself requires no bookkeeping beyond the atomic counters that Get, Set,
+// expiration and capacity eviction already maintain.
+func (c *Typed[K, V]) ReportTo(collector MetricsCollector) {
+	collector.Collect(c.Stats())
+}
@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStatsTracksHitsAndMisses(t *testing.T) {
+	c := NewTyped[string, int](time.Hour)
+	defer c.Close()
+
+	c.Set("a", 1)
+	c.Get("a")    // hit
+	c.Get("a")    // hit
+	c.Get("nope") // miss
+
+	stats := c.Stats()
+	if stats.Hits != 2 {
+		t.Fatalf("Hits = %d, want 2", stats.Hits)
+	}
+	if stats.Misses != 1 {
+		t.Fatalf("Misses = %d, want 1", stats.Misses)
+	}
+	if want := 2.0 / 3.0; stats.HitRatio != want {
+		t.Fatalf("HitRatio = %v, want %v", stats.HitRatio, want)
+	}
+}
+
+func TestStatsTracksInsertionsAndEvictions(t *testing.T) {
+	c := NewTypedWithOptions[string, int](time.Hour, Options{MaxEntries: 1, EvictionPolicy: PolicyLRU})
+	defer c.Close()
+
+	c.Set("a", 1)
+	c.Set("b", 2) // evicts "a" to stay within MaxEntries
+
+	stats := c.Stats()
+	if stats.Insertions != 2 {
+		t.Fatalf("Insertions = %d, want 2", stats.Insertions)
+	}
+	if stats.Evictions != 1 {
+		t.Fatalf("Evictions = %d, want 1", stats.Evictions)
+	}
+	if stats.Size != 1 {
+		t.Fatalf("Size = %d, want 1", stats.Size)
+	}
+}
+
+func TestStatsHitRatioZeroWithNoGets(t *testing.T) {
+	c := NewTyped[string, int](time.Hour)
+	defer c.Close()
+
+	if got := c.Stats().HitRatio; got != 0 {
+		t.Fatalf("HitRatio = %v, want 0 with no Get calls", got)
+	}
+}
+
+type fakeCollector struct {
+	last Stats
+	n    int
+}
+
+func (f *fakeCollector) Collect(stats Stats) {
+	f.last = stats
+	f.n++
+}
+
+func TestReportToPassesCurrentStats(t *testing.T) {
+	c := NewTyped[string, int](time.Hour)
+	defer c.Close()
+
+	c.Set("a", 1)
+	c.Get("a")
+
+	var fc fakeCollector
+	c.ReportTo(&fc)
+
+	if fc.n != 1 {
+		t.Fatalf("Collect called %d times, want 1", fc.n)
+	}
+	if fc.last.Hits != 1 {
+		t.Fatalf("reported Hits = %d, want 1", fc.last.Hits)
+	}
+	if fc.last.Size != 1 {
+		t.Fatalf("reported Size = %d, want 1", fc.last.Size)
+	}
+}
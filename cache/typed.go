@@ -0,0 +1,490 @@
+package cache
+
+import (
+	"container/heap"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EvictedFunc is called whenever an item leaves the cache, for any reason.
+type EvictedFunc[K comparable, V any] func(key K, value V, reason EvictReason)
+
+// ExpiredFunc is called when an item is removed specifically because it expired.
+// It is called in addition to, not instead of, any registered EvictedFunc.
+type ExpiredFunc[K comparable, V any] func(key K, value V)
+
+// NewItemFunc is called when a key that did not previously exist is inserted.
+type NewItemFunc[K comparable, V any] func(key K, value V)
+
+// Options configures optional cache behavior beyond the default TTL.
+type Options struct {
+	// SkipTTLExtension, when true, makes Get leave an item's expiration
+	// unchanged instead of resetting it to the cache's TTL from now on
+	// every read.
+	SkipTTLExtension bool
+
+	// MaxEntries bounds the number of items the cache holds. Once a Set
+	// would push the cache past MaxEntries, an item is evicted according
+	// to EvictionPolicy and reported to any OnEvicted callback with reason
+	// EvictCapacity. Zero (the default) leaves the cache unbounded.
+	//
+	// If MaxEntries is positive and EvictionPolicy is left at its zero
+	// value, the cache defaults to PolicyLRU rather than growing
+	// unbounded, since PolicyNone combined with a positive MaxEntries has
+	// no sensible meaning.
+	MaxEntries int
+
+	// EvictionPolicy selects which item to remove when MaxEntries is
+	// exceeded. It is ignored when MaxEntries is zero. See MaxEntries for
+	// the default applied when this is left unset.
+	EvictionPolicy EvictionPolicy
+}
+
+// Typed is a generic, thread-safe in-memory cache with expiration. It is the
+// engine behind Cache; use it directly to avoid boxing values in
+// interface{} and to keep type safety on Get.
+//
+// Typed is safe for concurrent use by multiple goroutines. Items are
+// tracked in a min-heap ordered by expiration, and a single background
+// goroutine sleeps exactly until the next item is due rather than polling
+// on a fixed interval.
+type Typed[K comparable, V any] struct {
+	mu    sync.RWMutex
+	items map[K]*item[K, V]
+	pq    priorityQueue[K, V]
+	ttl   time.Duration
+
+	skipTTLExtension bool
+
+	maxEntries     int
+	evictionPolicy EvictionPolicy
+	tracker        evictionTracker[K]
+
+	// Stats counters, updated atomically so Get can stay on the RLock fast
+	// path when TTL extension and capacity tracking are both disabled.
+	hits        uint64
+	misses      uint64
+	evictions   uint64
+	expirations uint64
+	insertions  uint64
+
+	// nextWake is the expiration time the expiration loop is currently
+	// sleeping until, or the zero Time if it is idle. It is only read and
+	// written while holding mu.
+	nextWake time.Time
+
+	expirationNotification chan bool
+	done                   chan struct{}
+	closeOnce              sync.Once
+
+	onEvicted EvictedFunc[K, V]
+	onExpired ExpiredFunc[K, V]
+	onNewItem NewItemFunc[K, V]
+}
+
+// item represents a cached value with its expiration time and its position
+// in the cache's priority queue.
+type item[K comparable, V any] struct {
+	key        K
+	value      V
+	expiration time.Time
+	heapIndex  int
+}
+
+// touch extends the item's expiration to ttl from now.
+func (it *item[K, V]) touch(ttl time.Duration) {
+	it.expiration = time.Now().Add(ttl)
+}
+
+// NewTyped creates a new Typed cache with the specified default TTL.
+// It starts a background goroutine that expires items as they come due.
+//
+// Example:
+//
+//	c := NewTyped[string, int](10 * time.Minute)
+//	defer c.Close()
+func NewTyped[K comparable, V any](ttl time.Duration) *Typed[K, V] {
+	return NewTypedWithOptions[K, V](ttl, Options{})
+}
+
+// NewTypedWithOptions creates a new Typed cache with the specified default
+// TTL and behavior options. See Options for details.
+func NewTypedWithOptions[K comparable, V any](ttl time.Duration, opts Options) *Typed[K, V] {
+	c := &Typed[K, V]{
+		items:                  make(map[K]*item[K, V]),
+		ttl:                    ttl,
+		skipTTLExtension:       opts.SkipTTLExtension,
+		maxEntries:             opts.MaxEntries,
+		expirationNotification: make(chan bool, 1),
+		done:                   make(chan struct{}),
+	}
+	if c.maxEntries > 0 {
+		c.evictionPolicy = opts.EvictionPolicy
+		if c.evictionPolicy == PolicyNone {
+			c.evictionPolicy = PolicyLRU
+		}
+	}
+	c.tracker = newEvictionTracker[K](c.evictionPolicy)
+	go c.expirationLoop()
+	return c
+}
+
+// OnEvicted registers a callback invoked whenever an item leaves the cache,
+// regardless of the reason. Callbacks run outside the cache's internal lock,
+// so it is safe for fn to call back into the cache (e.g. to re-insert a value).
+//
+// Passing nil clears the callback.
+func (c *Typed[K, V]) OnEvicted(fn EvictedFunc[K, V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onEvicted = fn
+}
+
+// OnExpired registers a callback invoked when an item is removed because its
+// TTL elapsed. It runs in addition to any OnEvicted callback, outside the
+// cache's internal lock.
+//
+// Passing nil clears the callback.
+func (c *Typed[K, V]) OnExpired(fn ExpiredFunc[K, V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onExpired = fn
+}
+
+// OnNewItem registers a callback invoked when a key that did not previously
+// exist is inserted into the cache. It runs outside the cache's internal lock.
+//
+// Passing nil clears the callback.
+func (c *Typed[K, V]) OnNewItem(fn NewItemFunc[K, V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onNewItem = fn
+}
+
+// Get retrieves a value from the cache.
+// It returns the value and true if found and not expired,
+// or the zero value of V and false otherwise.
+//
+// Unless the cache was created with Options.SkipTTLExtension, a successful
+// Get resets the item's expiration to the cache's TTL from now (which
+// shortens it for an item stored with a longer TTL via SetWithTTL), and
+// repositions it in the expiration heap.
+//
+// Get is safe for concurrent use.
+func (c *Typed[K, V]) Get(key K) (V, bool) {
+	trackingAccess := c.maxEntries > 0
+
+	if c.skipTTLExtension && !trackingAccess {
+		c.mu.RLock()
+		it, found := c.items[key]
+		if !found || time.Now().After(it.expiration) {
+			c.mu.RUnlock()
+			atomic.AddUint64(&c.misses, 1)
+			var zero V
+			return zero, false
+		}
+		value := it.value
+		c.mu.RUnlock()
+		atomic.AddUint64(&c.hits, 1)
+		return value, true
+	}
+
+	c.mu.Lock()
+	it, found := c.items[key]
+	if !found || time.Now().After(it.expiration) {
+		c.mu.Unlock()
+		atomic.AddUint64(&c.misses, 1)
+		var zero V
+		return zero, false
+	}
+	value := it.value
+	if !c.skipTTLExtension {
+		it.touch(c.ttl)
+		heap.Fix(&c.pq, it.heapIndex)
+		c.wakeIfEarlier(it.expiration)
+	}
+	if trackingAccess {
+		c.tracker.touch(key)
+	}
+	c.mu.Unlock()
+	atomic.AddUint64(&c.hits, 1)
+	return value, true
+}
+
+// Set stores a value in the cache with the default TTL.
+// If the key already exists, its value and expiration are updated.
+//
+// Set is safe for concurrent use.
+func (c *Typed[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.ttl)
+}
+
+// SetWithTTL stores a value with a custom TTL.
+// This allows different expiration times for different keys.
+//
+// If key already holds a value, the old value is reported to any OnEvicted
+// callback with reason EvictReplaced; otherwise the new item is reported to
+// any OnNewItem callback.
+//
+// A non-positive ttl is not rejected; it stores the item already expired, so
+// it is immediately invisible to Get and is reaped by the expiration
+// goroutine on its next wake. This is intentional: it lets callers use
+// SetWithTTL(key, value, 0) as a way to evict key without a separate
+// code path.
+func (c *Typed[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	result := c.setLocked(key, value, ttl)
+	c.mu.Unlock()
+
+	c.fireSetResult(key, value, result)
+}
+
+// fireSetResult fires the appropriate callbacks for the outcome of a
+// setLocked call: OnEvicted(EvictReplaced) or OnNewItem for the key that
+// was set, plus OnEvicted(EvictCapacity) for any item evicted to make room
+// for it.
+func (c *Typed[K, V]) fireSetResult(key K, value V, result setResult[K, V]) {
+	if result.existed {
+		c.fireEvicted(key, result.oldValue, EvictReplaced)
+	} else {
+		c.fireNewItem(key, value)
+	}
+	if result.evicted {
+		c.fireEvicted(result.evictedKey, result.evictedValue, EvictCapacity)
+	}
+}
+
+// setResult reports the outcome of setLocked so callers can fire the
+// appropriate callbacks after releasing the lock.
+type setResult[K comparable, V any] struct {
+	existed  bool
+	oldValue V
+
+	evicted      bool
+	evictedKey   K
+	evictedValue V
+}
+
+// setLocked stores value under key with an expiration ttl from now. c.mu
+// must already be held for writing; see setAbsoluteLocked for details.
+func (c *Typed[K, V]) setLocked(key K, value V, ttl time.Duration) setResult[K, V] {
+	return c.setAbsoluteLocked(key, value, time.Now().Add(ttl))
+}
+
+// setAbsoluteLocked stores value under key with an absolute expiration
+// time, pushing or repositioning it in the expiration heap as needed, and
+// evicts a victim if the insert pushes the cache past MaxEntries. c.mu must
+// already be held for writing; the caller fires callbacks after releasing
+// the lock based on the returned setResult.
+func (c *Typed[K, V]) setAbsoluteLocked(key K, value V, expiration time.Time) setResult[K, V] {
+	if old, existed := c.items[key]; existed {
+		oldValue := old.value
+		old.value = value
+		old.expiration = expiration
+		heap.Fix(&c.pq, old.heapIndex)
+		c.wakeIfEarlier(expiration)
+		c.tracker.touch(key)
+		return setResult[K, V]{existed: true, oldValue: oldValue}
+	}
+
+	it := &item[K, V]{key: key, value: value, expiration: expiration}
+	heap.Push(&c.pq, it)
+	c.items[key] = it
+	c.wakeIfEarlier(expiration)
+	c.tracker.add(key)
+	atomic.AddUint64(&c.insertions, 1)
+
+	result := setResult[K, V]{}
+	if c.maxEntries > 0 && len(c.items) > c.maxEntries {
+		if victimKey, ok := c.tracker.victim(); ok {
+			if victimItem, found := c.items[victimKey]; found {
+				delete(c.items, victimKey)
+				heap.Remove(&c.pq, victimItem.heapIndex)
+				c.tracker.remove(victimKey)
+				result.evicted = true
+				result.evictedKey = victimKey
+				result.evictedValue = victimItem.value
+			}
+		}
+	}
+	return result
+}
+
+// Delete removes an item from the cache.
+// It returns true if the item existed, false otherwise.
+func (c *Typed[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	it, found := c.items[key]
+	if found {
+		delete(c.items, key)
+		heap.Remove(&c.pq, it.heapIndex)
+		c.tracker.remove(key)
+	}
+	c.mu.Unlock()
+
+	if found {
+		c.fireEvicted(key, it.value, EvictDeleted)
+	}
+	return found
+}
+
+// Clear removes all items from the cache, reporting each one to any
+// OnEvicted callback with reason EvictCleared.
+func (c *Typed[K, V]) Clear() {
+	c.mu.Lock()
+	old := c.items
+	c.items = make(map[K]*item[K, V])
+	c.pq = nil
+	c.tracker = newEvictionTracker[K](c.evictionPolicy)
+	c.mu.Unlock()
+
+	for key, it := range old {
+		c.fireEvicted(key, it.value, EvictCleared)
+	}
+}
+
+// Len returns the number of items in the cache.
+// Note that this includes expired items that haven't been cleaned up yet.
+//
+// Deprecated: Use Count instead which excludes expired items.
+func (c *Typed[K, V]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.items)
+}
+
+// Count returns the number of non-expired items.
+// It is more accurate than Len but slower.
+//
+// See also: Len, Clear
+func (c *Typed[K, V]) Count() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := 0
+	now := time.Now()
+	for _, it := range c.items {
+		if now.Before(it.expiration) {
+			count++
+		}
+	}
+	return count
+}
+
+// Close stops the expiration goroutine and releases resources.
+// After Close is called, the cache should not be used.
+//
+// TODO: Add context support for graceful shutdown.
+func (c *Typed[K, V]) Close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
+}
+
+// wakeIfEarlier signals the expiration loop if expiration is sooner than
+// the wake time it is currently sleeping for. c.mu must be held.
+func (c *Typed[K, V]) wakeIfEarlier(expiration time.Time) {
+	if c.nextWake.IsZero() || expiration.Before(c.nextWake) {
+		select {
+		case c.expirationNotification <- true:
+		default:
+		}
+	}
+}
+
+// expirationLoop sleeps until the soonest-expiring item is due, removing it
+// (and any other items that have since expired) when it wakes. It wakes
+// early whenever a sooner-expiring item is inserted.
+func (c *Typed[K, V]) expirationLoop() {
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		c.mu.Lock()
+		if len(c.pq) == 0 {
+			c.nextWake = time.Time{}
+			c.mu.Unlock()
+
+			select {
+			case <-c.expirationNotification:
+				continue
+			case <-c.done:
+				return
+			}
+		}
+
+		next := c.pq[0].expiration
+		c.nextWake = next
+		c.mu.Unlock()
+
+		timer.Reset(time.Until(next))
+
+		select {
+		case <-timer.C:
+			c.removeExpired()
+		case <-c.expirationNotification:
+			timer.Stop()
+		case <-c.done:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// removeExpired pops every item that is due off the heap and reports each
+// one to any OnEvicted/OnExpired callback.
+func (c *Typed[K, V]) removeExpired() {
+	c.mu.Lock()
+	now := time.Now()
+	var expiredKeys []K
+	var expiredValues []V
+	for len(c.pq) > 0 && !now.Before(c.pq[0].expiration) {
+		it := heap.Pop(&c.pq).(*item[K, V])
+		delete(c.items, it.key)
+		c.tracker.remove(it.key)
+		expiredKeys = append(expiredKeys, it.key)
+		expiredValues = append(expiredValues, it.value)
+	}
+	c.mu.Unlock()
+
+	for i, key := range expiredKeys {
+		c.fireEvicted(key, expiredValues[i], EvictExpired)
+	}
+}
+
+// fireEvicted invokes the OnEvicted callback and, when reason is
+// EvictExpired, the OnExpired callback. Both run outside c.mu so that
+// callbacks may safely re-enter the cache.
+func (c *Typed[K, V]) fireEvicted(key K, value V, reason EvictReason) {
+	switch reason {
+	case EvictExpired:
+		atomic.AddUint64(&c.expirations, 1)
+	case EvictCapacity:
+		atomic.AddUint64(&c.evictions, 1)
+	}
+
+	c.mu.RLock()
+	onEvicted := c.onEvicted
+	onExpired := c.onExpired
+	c.mu.RUnlock()
+
+	if onEvicted != nil {
+		onEvicted(key, value, reason)
+	}
+	if reason == EvictExpired && onExpired != nil {
+		onExpired(key, value)
+	}
+}
+
+// fireNewItem invokes the OnNewItem callback outside c.mu.
+func (c *Typed[K, V]) fireNewItem(key K, value V) {
+	c.mu.RLock()
+	onNewItem := c.onNewItem
+	c.mu.RUnlock()
+
+	if onNewItem != nil {
+		onNewItem(key, value)
+	}
+}
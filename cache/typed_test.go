@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestExpirationLoopWakesEarlyForSoonerItem verifies that inserting an item
+// with an earlier expiration than the one the background goroutine is
+// currently sleeping for wakes it up in time, rather than waiting for the
+// originally-scheduled timer to fire.
+func TestExpirationLoopWakesEarlyForSoonerItem(t *testing.T) {
+	c := NewTyped[string, int](time.Hour)
+	defer c.Close()
+
+	c.Set("late", 1)
+	c.SetWithTTL("soon", 2, 20*time.Millisecond)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		// Len, unlike Count and Get, includes expired items still in the
+		// map, so it only drops once the goroutine has removed "soon".
+		if c.Len() == 1 {
+			if _, found := c.Get("soon"); !found {
+				return
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("soon-expiring item was not removed in time; expiration loop did not wake early")
+}
+
+// TestExpirationRemovesAllDueItems verifies that when the expiration goroutine
+// wakes, it drains every item that has become due, not just the one at the
+// head of the heap.
+func TestExpirationRemovesAllDueItems(t *testing.T) {
+	c := NewTyped[string, int](10 * time.Millisecond)
+	defer c.Close()
+
+	for i := 0; i < 10; i++ {
+		c.Set(string(rune('a'+i)), i)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if c.Len() == 0 {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("expected all items to be removed, %d remaining", c.Len())
+}
+
+// TestGetExtendsExpirationAndReordersHeap verifies that a Get on an item
+// repositions it in the expiration heap, so a frequently-read item does not
+// expire ahead of one that has gone untouched.
+func TestGetExtendsExpirationAndReordersHeap(t *testing.T) {
+	c := NewTyped[string, int](40 * time.Millisecond)
+	defer c.Close()
+
+	c.Set("hot", 1)
+	c.Set("cold", 2)
+
+	// Keep refreshing "hot" well past "cold"'s original TTL.
+	refreshDeadline := time.Now().Add(80 * time.Millisecond)
+	for time.Now().Before(refreshDeadline) {
+		if _, found := c.Get("hot"); !found {
+			t.Fatal("hot item expired even though it was being refreshed")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, found := c.Get("cold"); !found {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if _, found := c.Get("hot"); !found {
+		t.Fatal("hot item expired despite being refreshed more recently than cold")
+	}
+}
+
+// TestConcurrentSetGetNoRace exercises Set/Get from many goroutines
+// concurrently; it exists to be run under -race to catch data races in the
+// heap and tracker bookkeeping, not to assert on values.
+func TestConcurrentSetGetNoRace(t *testing.T) {
+	c := NewTyped[int, int](50 * time.Millisecond)
+	defer c.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				c.Set(i, j)
+				c.Get(i)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// TestCloseStopsExpirationLoop verifies that Close signals the background
+// goroutine to stop by closing its done channel, and that a second Close
+// (which would panic on a double-close of the done channel if not guarded)
+// is safe.
+func TestCloseStopsExpirationLoop(t *testing.T) {
+	c := NewTyped[string, int](time.Hour)
+	c.Close()
+	select {
+	case <-c.done:
+	default:
+		t.Fatal("Close did not close the done channel")
+	}
+	c.Close()
+}